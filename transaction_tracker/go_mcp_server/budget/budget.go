@@ -0,0 +1,68 @@
+// Package budget implements budgeting on top of raw transactions:
+// per-category spending limits, keyword/regex rules that auto-assign a
+// category on insert, and simple anomaly detection against each
+// category's trailing spending history.
+package budget
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidPattern is returned by CreateRule when IsRegex is set and
+// Pattern fails to compile.
+var ErrInvalidPattern = errors.New("budget: invalid regex pattern")
+
+// ErrRuleNotFound is returned by DeleteRule when id matches no rule.
+var ErrRuleNotFound = errors.New("budget: rule not found")
+
+// Budget is a per-category spending limit for a period (currently only
+// "monthly" periods are computed by Status).
+type Budget struct {
+	ID          int64   `json:"id"`
+	Category    string  `json:"category"`
+	Period      string  `json:"period"`
+	LimitAmount float64 `json:"limit_amount"`
+	Rollover    bool    `json:"rollover"`
+}
+
+// Rule auto-assigns Category to any transaction whose description
+// matches Pattern. Pattern is a plain substring unless IsRegex is set,
+// in which case it's a Go regexp.
+type Rule struct {
+	ID       int64  `json:"id"`
+	Pattern  string `json:"pattern"`
+	IsRegex  bool   `json:"is_regex"`
+	Category string `json:"category"`
+}
+
+// Status is one category's budget position for a month.
+type Status struct {
+	Category    string  `json:"category"`
+	Spent       float64 `json:"spent"`
+	Limit       float64 `json:"limit_amount"`
+	Remaining   float64 `json:"remaining"`
+	PercentUsed float64 `json:"percent_used"`
+	// Anomalous is set when Spent is more than anomalyStdDevs standard
+	// deviations above the category's trailing 6-month mean spend.
+	Anomalous bool `json:"anomalous"`
+}
+
+// Repo is the storage interface the budgets HTTP handlers depend on.
+type Repo interface {
+	CreateBudget(ctx context.Context, b Budget) (int64, error)
+	// CreateRule returns ErrInvalidPattern if IsRegex is set and Pattern
+	// fails to compile.
+	CreateRule(ctx context.Context, r Rule) (int64, error)
+	// DeleteRule returns ErrRuleNotFound if id matches no rule.
+	DeleteRule(ctx context.Context, id int64) error
+	Rules(ctx context.Context) ([]Rule, error)
+	// Categorize returns the category of the first rule matching
+	// description, and false if no rule matches.
+	Categorize(ctx context.Context, description string) (string, bool)
+	Status(ctx context.Context, month string) ([]Status, error)
+	// Reclassify reruns every rule, in insertion order, against every
+	// transaction's description and reports how many rows changed
+	// category.
+	Reclassify(ctx context.Context) (int, error)
+}
@@ -0,0 +1,46 @@
+package budget
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// compiledRule is a Rule with its regexp pre-compiled, if applicable.
+type compiledRule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+func compileRules(rules []Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, len(rules))
+	for i, r := range rules {
+		cr := compiledRule{Rule: r}
+		if r.IsRegex {
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: invalid regex %q: %w", r.ID, r.Pattern, err)
+			}
+			cr.re = re
+		}
+		compiled[i] = cr
+	}
+	return compiled, nil
+}
+
+// matchCategory returns the category of the first rule whose pattern
+// matches description, and true if one matched.
+func matchCategory(rules []compiledRule, description string) (string, bool) {
+	for _, r := range rules {
+		if r.IsRegex {
+			if r.re.MatchString(description) {
+				return r.Category, true
+			}
+			continue
+		}
+		if strings.Contains(strings.ToLower(description), strings.ToLower(r.Pattern)) {
+			return r.Category, true
+		}
+	}
+	return "", false
+}
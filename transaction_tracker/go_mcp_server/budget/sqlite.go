@@ -0,0 +1,334 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"budgify/db"
+)
+
+// anomalyStdDevs is how many standard deviations above a category's
+// trailing 6-month mean spend counts as anomalous.
+const anomalyStdDevs = 2.0
+
+const trailingMonths = 6
+
+// SQLiteRepo implements Repo against budgets, rules, and the existing
+// transactions table in a sqlite database.
+type SQLiteRepo struct {
+	db *db.DB
+}
+
+// NewSQLiteRepo wraps conn in a Repo.
+func NewSQLiteRepo(conn *db.DB) *SQLiteRepo {
+	return &SQLiteRepo{db: conn}
+}
+
+const schemaSQL = `
+CREATE TABLE IF NOT EXISTS budgets (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	category     TEXT NOT NULL,
+	period       TEXT NOT NULL DEFAULT 'monthly',
+	limit_amount REAL NOT NULL,
+	rollover     INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS rules (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	pattern  TEXT NOT NULL,
+	is_regex INTEGER NOT NULL DEFAULT 0,
+	category TEXT NOT NULL
+);
+`
+
+// EnsureSchema creates the budgets and rules tables if they don't already exist.
+func EnsureSchema(ctx context.Context, conn *db.DB) error {
+	_, err := conn.ExecContext(ctx, schemaSQL)
+	return err
+}
+
+func (r *SQLiteRepo) CreateBudget(ctx context.Context, b Budget) (int64, error) {
+	if b.Period == "" {
+		b.Period = "monthly"
+	}
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO budgets (category, period, limit_amount, rollover) VALUES (?, ?, ?, ?)`,
+		b.Category, b.Period, b.LimitAmount, b.Rollover)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *SQLiteRepo) CreateRule(ctx context.Context, rule Rule) (int64, error) {
+	if rule.IsRegex {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return 0, fmt.Errorf("%w: %s: %v", ErrInvalidPattern, rule.Pattern, err)
+		}
+	}
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO rules (pattern, is_regex, category) VALUES (?, ?, ?)`,
+		rule.Pattern, rule.IsRegex, rule.Category)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *SQLiteRepo) DeleteRule(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM rules WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteRepo) Rules(ctx context.Context) ([]Rule, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, pattern, is_regex, category FROM rules ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []Rule
+	for rows.Next() {
+		var rule Rule
+		if err := rows.Scan(&rule.ID, &rule.Pattern, &rule.IsRegex, &rule.Category); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *SQLiteRepo) Status(ctx context.Context, month string) ([]Status, error) {
+	target, err := time.Parse("2006-01", month)
+	if err != nil {
+		return nil, err
+	}
+
+	currentSpend, err := r.spendByCategoryForMonth(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := r.trailingMonthlySpend(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := r.budgetsByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]struct{}, len(currentSpend)+len(budgets))
+	for c := range currentSpend {
+		categories[c] = struct{}{}
+	}
+	for c := range budgets {
+		categories[c] = struct{}{}
+	}
+
+	statuses := make([]Status, 0, len(categories))
+	for category := range categories {
+		spent := currentSpend[category]
+		b := budgets[category]
+
+		s := Status{
+			Category:  category,
+			Spent:     round2(spent),
+			Limit:     b.LimitAmount,
+			Remaining: round2(b.LimitAmount - spent),
+		}
+		if b.LimitAmount > 0 {
+			s.PercentUsed = round2(spent / b.LimitAmount * 100)
+		}
+
+		mean, stddev := meanStdDev(history[category])
+		if len(history[category]) > 0 && spent > mean+anomalyStdDevs*stddev {
+			s.Anomalous = true
+		}
+
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+func (r *SQLiteRepo) spendByCategoryForMonth(ctx context.Context, month string) (map[string]float64, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT category, SUM(amount) FROM transactions WHERE strftime('%Y-%m', date) = ? GROUP BY category`,
+		month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spend := make(map[string]float64)
+	for rows.Next() {
+		var category string
+		var total float64
+		if err := rows.Scan(&category, &total); err != nil {
+			return nil, err
+		}
+		spend[category] = total
+	}
+	return spend, rows.Err()
+}
+
+// trailingMonthlySpend returns, per category, one spend total for each of
+// the trailingMonths months before target, zero-filling months with no
+// transactions so the mean/stddev reflect the full window.
+func (r *SQLiteRepo) trailingMonthlySpend(ctx context.Context, target time.Time) (map[string][]float64, error) {
+	end := target
+	start := target.AddDate(0, -trailingMonths, 0)
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT category, strftime('%Y-%m', date) AS ym, SUM(amount)
+		 FROM transactions
+		 WHERE date >= ? AND date < ?
+		 GROUP BY category, ym`,
+		start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := make([]string, trailingMonths)
+	for i := range months {
+		months[trailingMonths-1-i] = target.AddDate(0, -(i + 1), 0).Format("2006-01")
+	}
+	monthIndex := make(map[string]int, trailingMonths)
+	for i, m := range months {
+		monthIndex[m] = i
+	}
+
+	byCategory := make(map[string][]float64)
+	for rows.Next() {
+		var category, ym string
+		var total float64
+		if err := rows.Scan(&category, &ym, &total); err != nil {
+			return nil, err
+		}
+		idx, ok := monthIndex[ym]
+		if !ok {
+			continue
+		}
+		if _, exists := byCategory[category]; !exists {
+			byCategory[category] = make([]float64, trailingMonths)
+		}
+		byCategory[category][idx] = total
+	}
+	return byCategory, rows.Err()
+}
+
+func (r *SQLiteRepo) budgetsByCategory(ctx context.Context) (map[string]Budget, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, category, period, limit_amount, rollover FROM budgets`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := make(map[string]Budget)
+	for rows.Next() {
+		var b Budget
+		if err := rows.Scan(&b.ID, &b.Category, &b.Period, &b.LimitAmount, &b.Rollover); err != nil {
+			return nil, err
+		}
+		budgets[b.Category] = b
+	}
+	return budgets, rows.Err()
+}
+
+// Categorize implements Repo.
+func (r *SQLiteRepo) Categorize(ctx context.Context, description string) (string, bool) {
+	rules, err := r.Rules(ctx)
+	if err != nil {
+		return "", false
+	}
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return "", false
+	}
+	return matchCategory(compiled, description)
+}
+
+func (r *SQLiteRepo) Reclassify(ctx context.Context) (int, error) {
+	rules, err := r.Rules(ctx)
+	if err != nil {
+		return 0, err
+	}
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return 0, err
+	}
+	if len(compiled) == 0 {
+		return 0, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, description, category FROM transactions`)
+	if err != nil {
+		return 0, err
+	}
+	type update struct {
+		id       int64
+		category string
+	}
+	var updates []update
+	for rows.Next() {
+		var id int64
+		var description, category string
+		if err := rows.Scan(&id, &description, &category); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if newCategory, matched := matchCategory(compiled, description); matched && newCategory != category {
+			updates = append(updates, update{id: id, category: newCategory})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	for _, u := range updates {
+		if _, err := r.db.ExecContext(ctx, `UPDATE transactions SET category = ? WHERE id = ?`, u.category, u.id); err != nil {
+			return 0, err
+		}
+	}
+	return len(updates), nil
+}
+
+func meanStdDev(values []float64) (mean, stddev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean = sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return mean, math.Sqrt(variance)
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+var _ Repo = (*SQLiteRepo)(nil)
@@ -0,0 +1,162 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"budgify/db"
+)
+
+func newTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	conn, err := db.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open in-memory db: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := context.Background()
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE transactions (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			date        TEXT NOT NULL,
+			category    TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			amount      REAL NOT NULL
+		);
+	`); err != nil {
+		t.Fatalf("create transactions table: %v", err)
+	}
+	if err := EnsureSchema(ctx, conn); err != nil {
+		t.Fatalf("ensure budget schema: %v", err)
+	}
+	return conn
+}
+
+func insertTx(t *testing.T, conn *db.DB, date, category, description string, amount float64) {
+	t.Helper()
+	if _, err := conn.Exec(`INSERT INTO transactions (date, category, description, amount) VALUES (?, ?, ?, ?)`,
+		date, category, description, amount); err != nil {
+		t.Fatalf("insert transaction: %v", err)
+	}
+}
+
+func TestSQLiteRepo_Status(t *testing.T) {
+	conn := newTestDB(t)
+	repo := NewSQLiteRepo(conn)
+	ctx := context.Background()
+
+	for _, m := range []string{"2026-01", "2026-02", "2026-03", "2026-04", "2026-05", "2026-06"} {
+		insertTx(t, conn, m+"-10", "groceries", "weekly shop", 100)
+	}
+	// A spike in the target month, well above the trailing mean.
+	insertTx(t, conn, "2026-07-05", "groceries", "big shop", 500)
+
+	if _, err := repo.CreateBudget(ctx, Budget{Category: "groceries", LimitAmount: 300}); err != nil {
+		t.Fatalf("CreateBudget() error = %v", err)
+	}
+
+	statuses, err := repo.Status(ctx, "2026-07")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	var got *Status
+	for i := range statuses {
+		if statuses[i].Category == "groceries" {
+			got = &statuses[i]
+		}
+	}
+	if got == nil {
+		t.Fatal("Status() missing groceries category")
+	}
+	if got.Spent != 500 {
+		t.Errorf("Spent = %v, want 500", got.Spent)
+	}
+	if got.Remaining != -200 {
+		t.Errorf("Remaining = %v, want -200", got.Remaining)
+	}
+	if !got.Anomalous {
+		t.Error("Anomalous = false, want true for a spend well above trailing mean")
+	}
+}
+
+func TestSQLiteRepo_Reclassify(t *testing.T) {
+	conn := newTestDB(t)
+	repo := NewSQLiteRepo(conn)
+	ctx := context.Background()
+
+	insertTx(t, conn, "2026-01-01", "uncategorized", "STARBUCKS #123", 5.50)
+	insertTx(t, conn, "2026-01-02", "uncategorized", "NETFLIX.COM", 15.99)
+
+	if _, err := repo.CreateRule(ctx, Rule{Pattern: "starbucks", Category: "dining"}); err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+	if _, err := repo.CreateRule(ctx, Rule{Pattern: "(?i)netflix", IsRegex: true, Category: "subscriptions"}); err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+
+	n, err := repo.Reclassify(ctx)
+	if err != nil {
+		t.Fatalf("Reclassify() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Reclassify() = %d, want 2", n)
+	}
+
+	var category string
+	if err := conn.QueryRow(`SELECT category FROM transactions WHERE description = 'STARBUCKS #123'`).Scan(&category); err != nil {
+		t.Fatalf("query category: %v", err)
+	}
+	if category != "dining" {
+		t.Errorf("category = %q, want dining", category)
+	}
+}
+
+func TestSQLiteRepo_CreateRule_InvalidPattern(t *testing.T) {
+	conn := newTestDB(t)
+	repo := NewSQLiteRepo(conn)
+	ctx := context.Background()
+
+	if _, err := repo.CreateRule(ctx, Rule{Pattern: "(unterminated", IsRegex: true, Category: "dining"}); !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("CreateRule() with bad regex error = %v, want ErrInvalidPattern", err)
+	}
+
+	rules, err := repo.Rules(ctx)
+	if err != nil {
+		t.Fatalf("Rules() error = %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("Rules() = %d rules, want 0 after a rejected CreateRule", len(rules))
+	}
+}
+
+func TestSQLiteRepo_DeleteRule(t *testing.T) {
+	conn := newTestDB(t)
+	repo := NewSQLiteRepo(conn)
+	ctx := context.Background()
+
+	id, err := repo.CreateRule(ctx, Rule{Pattern: "starbucks", Category: "dining"})
+	if err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+
+	if err := repo.DeleteRule(ctx, id); err != nil {
+		t.Fatalf("DeleteRule() error = %v", err)
+	}
+
+	rules, err := repo.Rules(ctx)
+	if err != nil {
+		t.Fatalf("Rules() error = %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("Rules() = %d rules, want 0 after delete", len(rules))
+	}
+
+	if err := repo.DeleteRule(ctx, id); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("DeleteRule() on already-deleted id error = %v, want ErrRuleNotFound", err)
+	}
+}
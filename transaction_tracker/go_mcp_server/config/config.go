@@ -0,0 +1,69 @@
+// Package config loads Budgify's runtime configuration from a YAML file,
+// falling back to sensible defaults when no file (or no -conf flag) is
+// given.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is Budgify's top-level runtime configuration.
+type Config struct {
+	// BindAddr is the address the HTTP/FCGI server listens on, e.g.
+	// ":8080". It is not used in "mcp" mode, which serves over stdio.
+	BindAddr string `yaml:"bind_addr"`
+
+	// Mode selects how the server is exposed: "http", "fcgi", or "mcp".
+	Mode string `yaml:"mode"`
+
+	// BaseDir is the directory a relative sqlite3 DSN is resolved
+	// against. Other paths (such as imported statement files) are not
+	// resolved against it.
+	BaseDir string `yaml:"base_dir"`
+
+	DB DBConfig `yaml:"db"`
+}
+
+// DBConfig selects and configures the SQL backend.
+type DBConfig struct {
+	Type DBType `yaml:"type"`
+	// DSN is the driver-specific data source name. For sqlite3 this is a
+	// file path; for mysql/postgres it's a connection string.
+	DSN string `yaml:"dsn"`
+}
+
+// Default returns Budgify's out-of-the-box configuration: a local sqlite
+// file served over plain HTTP on :8080.
+func Default() *Config {
+	return &Config{
+		BindAddr: ":8080",
+		Mode:     "http",
+		BaseDir:  ".",
+		DB: DBConfig{
+			Type: DBTypeSQLite3,
+			DSN:  "budget.db",
+		},
+	}
+}
+
+// Load reads a YAML config file at path and overlays it onto Default().
+// An empty path is not an error: Default() is returned unchanged so
+// Budgify can run with zero configuration.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import "fmt"
+
+// DBType identifies which SQL driver Budgify should use.
+type DBType int
+
+const (
+	// DBTypeSQLite3 stores data in a local sqlite file. This is the default.
+	DBTypeSQLite3 DBType = iota
+	DBTypeMySQL
+	DBTypePostgres
+)
+
+func (t DBType) String() string {
+	switch t {
+	case DBTypeSQLite3:
+		return "sqlite3"
+	case DBTypeMySQL:
+		return "mysql"
+	case DBTypePostgres:
+		return "postgres"
+	default:
+		return "unknown"
+	}
+}
+
+// DBTypeFromString parses one of "sqlite3", "mysql", "postgres".
+func DBTypeFromString(s string) (DBType, error) {
+	switch s {
+	case "", "sqlite3":
+		return DBTypeSQLite3, nil
+	case "mysql":
+		return DBTypeMySQL, nil
+	case "postgres":
+		return DBTypePostgres, nil
+	default:
+		return 0, fmt.Errorf("config: unknown db type %q (want sqlite3, mysql, or postgres)", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so DBType can be read
+// directly out of YAML config.
+func (t *DBType) UnmarshalText(text []byte) error {
+	parsed, err := DBTypeFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DBType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
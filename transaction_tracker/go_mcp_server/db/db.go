@@ -0,0 +1,109 @@
+// Package db wraps database/sql so that all of Budgify's callers share
+// one connection with a single, consistent concurrency policy: sqlite
+// serializes writers, so writes go through a mutex while reads (and,
+// under WAL, readers overlapping a writer) stay concurrent. mysql and
+// postgres already provide real row/table-level write concurrency, so
+// writes against those backends are left unserialized.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// DB wraps *sql.DB, serializing writes with a mutex when the underlying
+// driver is sqlite3. Reads are never serialized: in WAL mode sqlite lets
+// readers proceed concurrently with a single writer, so
+// QueryContext/QueryRowContext are left as the embedded *sql.DB's
+// methods.
+type DB struct {
+	*sql.DB
+	mu              sync.Mutex
+	serializeWrites bool
+}
+
+// Open opens driverName/dsn and, for sqlite3, enables WAL journaling,
+// foreign key enforcement, and a busy timeout so concurrent
+// readers/writers back off instead of immediately erroring with
+// "database is locked". It also enables this DB's write-serializing
+// mutex for sqlite3 only; other drivers handle their own write
+// concurrency.
+func Open(driverName, dsn string) (*DB, error) {
+	sqlDB, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	serializeWrites := driverName == "sqlite3"
+	if serializeWrites {
+		for _, pragma := range []string{
+			"PRAGMA journal_mode=WAL;",
+			"PRAGMA foreign_keys=ON;",
+			"PRAGMA busy_timeout=5000;",
+		} {
+			if _, err := sqlDB.Exec(pragma); err != nil {
+				sqlDB.Close()
+				return nil, err
+			}
+		}
+	}
+
+	return &DB{DB: sqlDB, serializeWrites: serializeWrites}, nil
+}
+
+// ExecContext serializes writes against the rest of the process when
+// serializeWrites is set (sqlite3 only).
+func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if d.serializeWrites {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+	}
+	return d.DB.ExecContext(ctx, query, args...)
+}
+
+// Exec serializes writes against the rest of the process when
+// serializeWrites is set (sqlite3 only).
+func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return d.ExecContext(context.Background(), query, args...)
+}
+
+// Tx wraps *sql.Tx, holding the DB's write lock (sqlite3 only) for the
+// transaction's entire lifetime and releasing it on Commit or Rollback. Callers
+// conventionally `defer tx.Rollback()` right after a successful
+// BeginTx, so unlock is idempotent: it's a no-op once Commit or
+// Rollback has already run it.
+type Tx struct {
+	*sql.Tx
+	unlockOnce *sync.Once
+	unlock     func()
+}
+
+// Commit releases the write lock after committing.
+func (t *Tx) Commit() error {
+	defer t.unlockOnce.Do(t.unlock)
+	return t.Tx.Commit()
+}
+
+// Rollback releases the write lock after rolling back.
+func (t *Tx) Rollback() error {
+	defer t.unlockOnce.Do(t.unlock)
+	return t.Tx.Rollback()
+}
+
+// BeginTx acquires the write lock for the duration of the transaction
+// when serializeWrites is set (sqlite3 only), since a transaction may
+// issue writes at any point before Commit or Rollback is called.
+func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	unlock := func() {}
+	if d.serializeWrites {
+		d.mu.Lock()
+		unlock = d.mu.Unlock
+	}
+	tx, err := d.DB.BeginTx(ctx, opts)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	return &Tx{Tx: tx, unlockOnce: &sync.Once{}, unlock: unlock}, nil
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"budgify/budget"
+	"budgify/repository"
+)
+
+// validateTransaction applies request-scoped validation before a
+// transaction is written to the repository.
+func validateTransaction(t repository.Transaction) error {
+	if t.Date == "" {
+		return fmt.Errorf("date is required")
+	}
+	if t.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if t.Amount == 0 {
+		return fmt.Errorf("amount must be non-zero")
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("encode response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// handleListTransactions handles GET /transactions with optional
+// date-range, category, amount, text-search, pagination, and sort
+// query parameters.
+func (s *Server) handleListTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := repository.ListFilter{
+		Since:    q.Get("since"),
+		Until:    q.Get("until"),
+		Category: q.Get("category"),
+		Search:   q.Get("q"),
+		SortBy:   q.Get("sort"),
+		SortDesc: q.Get("order") == "desc",
+	}
+
+	if v := q.Get("min_amount"); v != "" {
+		amt, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid min_amount: %w", err))
+			return
+		}
+		filter.MinAmount = &amt
+	}
+	if v := q.Get("max_amount"); v != "" {
+		amt, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid max_amount: %w", err))
+			return
+		}
+		filter.MaxAmount = &amt
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %w", err))
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := q.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid offset: %w", err))
+			return
+		}
+		filter.Offset = offset
+	}
+
+	results, err := s.repo.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// handleGetTransaction handles GET /transactions/{id}.
+func (s *Server) handleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	t, err := s.repo.Get(r.Context(), id)
+	if err == repository.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// handleCreateTransaction handles POST /transactions.
+func (s *Server) handleCreateTransaction(w http.ResponseWriter, r *http.Request) {
+	var t repository.Transaction
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if t.Category == "" {
+		if category, ok := s.budgets.Categorize(r.Context(), t.Description); ok {
+			t.Category = category
+		}
+	}
+	if err := validateTransaction(t); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := s.repo.Create(r.Context(), t)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	t.ID = id
+	writeJSON(w, http.StatusCreated, t)
+}
+
+// handleUpdateTransaction handles PUT /transactions/{id}.
+func (s *Server) handleUpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var t repository.Transaction
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := validateTransaction(t); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.repo.Update(r.Context(), id, t); err == repository.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	t.ID = id
+	writeJSON(w, http.StatusOK, t)
+}
+
+// handleDeleteTransaction handles DELETE /transactions/{id}.
+func (s *Server) handleDeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.repo.Delete(r.Context(), id); err == repository.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListCategories handles GET /categories.
+func (s *Server) handleListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.repo.Categories(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, categories)
+}
+
+// handleSpendSummary handles GET /spend/summary?group_by=month|category|week.
+func (s *Server) handleSpendSummary(w http.ResponseWriter, r *http.Request) {
+	groupBy := r.URL.Query().Get("group_by")
+	summary, err := s.repo.SpendSummary(r.Context(), groupBy)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleCreateBudget handles POST /budgets.
+func (s *Server) handleCreateBudget(w http.ResponseWriter, r *http.Request) {
+	var b budget.Budget
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if b.Category == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("category is required"))
+		return
+	}
+	if b.LimitAmount <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("limit_amount must be positive"))
+		return
+	}
+
+	id, err := s.budgets.CreateBudget(r.Context(), b)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	b.ID = id
+	writeJSON(w, http.StatusCreated, b)
+}
+
+// handleCreateRule handles POST /rules.
+func (s *Server) handleCreateRule(w http.ResponseWriter, r *http.Request) {
+	var rule budget.Rule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if rule.Pattern == "" || rule.Category == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("pattern and category are required"))
+		return
+	}
+
+	id, err := s.budgets.CreateRule(r.Context(), rule)
+	if errors.Is(err, budget.ErrInvalidPattern) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	rule.ID = id
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+// handleDeleteRule handles DELETE /rules/{id}.
+func (s *Server) handleDeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.budgets.DeleteRule(r.Context(), id); err == budget.ErrRuleNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleBudgetStatus handles GET /budgets/status?month=YYYY-MM.
+func (s *Server) handleBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	month := r.URL.Query().Get("month")
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+
+	statuses, err := s.budgets.Status(r.Context(), month)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+// handleReclassify handles POST /transactions/reclassify, rerunning
+// every rule against every existing transaction's description.
+func (s *Server) handleReclassify(w http.ResponseWriter, r *http.Request) {
+	updated, err := s.budgets.Reclassify(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"reclassified": updated})
+}
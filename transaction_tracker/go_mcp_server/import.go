@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"budgify/config"
+	"budgify/importer"
+)
+
+// handleImport handles POST /import?profile=chase_checking, accepting a
+// multipart file upload of a CSV or OFX/QFX bank statement export.
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	profileName := r.URL.Query().Get("profile")
+	if profileName == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("missing required ?profile= query parameter"))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("missing file upload: %w", err))
+		return
+	}
+	defer file.Close()
+
+	profile, err := s.profiles.Get(r.Context(), profileName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	records, err := parseStatement(file, header.Filename, profile)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	summary, err := importer.NewPipeline(s.db, s.budgets).Run(r.Context(), records)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleSaveImportProfile handles POST /import/profiles, persisting the
+// posted Profile so it can later be referenced by name from
+// POST /import?profile=....
+func (s *Server) handleSaveImportProfile(w http.ResponseWriter, r *http.Request) {
+	var p importer.Profile
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if p.Name == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("name is required"))
+		return
+	}
+
+	if err := s.profiles.Save(r.Context(), p); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, p)
+}
+
+// parseStatement dispatches to the CSV or OFX/QFX parser based on
+// filename extension.
+func parseStatement(r io.Reader, filename string, profile importer.Profile) ([]importer.Record, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return importer.ParseCSV(r, profile)
+	case ".ofx", ".qfx":
+		return importer.ParseOFX(r)
+	default:
+		return nil, fmt.Errorf("import: unsupported file extension %q (want .csv, .ofx, or .qfx)", filepath.Ext(filename))
+	}
+}
+
+// runImportCLI implements `budgify import [-profile NAME] <file>`,
+// reusing the exact same parse -> dedupe -> categorize -> insert path as
+// the HTTP /import endpoint.
+func runImportCLI(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	profileName := fs.String("profile", "", "account profile name (see POST /import docs)")
+	confPath := fs.String("conf", os.Getenv("BUDGIFY_CONF"), "path to a YAML config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: budgify import [-profile NAME] <file>")
+	}
+	path := fs.Arg(0)
+
+	cfg, err := config.Load(*confPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	db, err := OpenDB(cfg.DB, cfg.BaseDir)
+	if err != nil {
+		return fmt.Errorf("open db: %w", err)
+	}
+	defer db.Close()
+
+	server := NewServer(db)
+
+	profile := importer.Profile{Name: *profileName}
+	if *profileName != "" {
+		profile, err = server.profiles.Get(context.Background(), *profileName)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	records, err := parseStatement(f, path, profile)
+	if err != nil {
+		return err
+	}
+
+	summary, err := importer.NewPipeline(db, server.budgets).Run(context.Background(), records)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(summary)
+}
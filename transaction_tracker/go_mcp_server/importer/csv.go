@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseCSV reads a bank statement CSV using profile's column mapping.
+// The first row must be a header naming the mapped columns.
+func ParseCSV(r io.Reader, profile Profile) ([]Record, error) {
+	profile = profile.withDefaults()
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("importer: read CSV header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	dateIdx, ok := index[strings.ToLower(profile.DateColumn)]
+	if !ok {
+		return nil, fmt.Errorf("importer: CSV missing date column %q", profile.DateColumn)
+	}
+	amountIdx, ok := index[strings.ToLower(profile.AmountColumn)]
+	if !ok {
+		return nil, fmt.Errorf("importer: CSV missing amount column %q", profile.AmountColumn)
+	}
+	descIdx, ok := index[strings.ToLower(profile.DescriptionColumn)]
+	if !ok {
+		return nil, fmt.Errorf("importer: CSV missing description column %q", profile.DescriptionColumn)
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		date, err := time.Parse(profile.DateFormat, strings.TrimSpace(row[dateIdx]))
+		if err != nil {
+			return nil, fmt.Errorf("importer: parse date %q: %w", row[dateIdx], err)
+		}
+		amount, err := strconv.ParseFloat(normalizeAmount(row[amountIdx]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("importer: parse amount %q: %w", row[amountIdx], err)
+		}
+
+		records = append(records, Record{
+			Date:        date.Format("2006-01-02"),
+			Amount:      amount,
+			Description: strings.TrimSpace(row[descIdx]),
+		})
+	}
+	return records, nil
+}
+
+// normalizeAmount strips currency symbols, thousands separators, and
+// parenthesized-negative notation ("(12.50)" -> "-12.50") that bank
+// exports commonly use.
+func normalizeAmount(s string) string {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	if negative {
+		s = s[1 : len(s)-1]
+	}
+	s = strings.NewReplacer("$", "", ",", "").Replace(s)
+	if negative {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		profile Profile
+		want    []Record
+		wantErr bool
+	}{
+		{
+			name: "default column names",
+			csv:  "date,amount,description\n2026-01-05,42.50,Trader Joe's\n2026-01-20,-61.10,Refund\n",
+			want: []Record{
+				{Date: "2026-01-05", Amount: 42.50, Description: "Trader Joe's"},
+				{Date: "2026-01-20", Amount: -61.10, Description: "Refund"},
+			},
+		},
+		{
+			name:    "custom column mapping and date format",
+			csv:     "Transaction Date,Desc,Amt\n01/05/2026,Whole Foods,$61.10\n",
+			profile: Profile{DateColumn: "Transaction Date", DescriptionColumn: "Desc", AmountColumn: "Amt", DateFormat: "01/02/2006"},
+			want:    []Record{{Date: "2026-01-05", Amount: 61.10, Description: "Whole Foods"}},
+		},
+		{
+			name: "parenthesized negative amount",
+			csv:  "date,amount,description\n2026-01-05,(12.50),Fee\n",
+			want: []Record{{Date: "2026-01-05", Amount: -12.50, Description: "Fee"}},
+		},
+		{
+			name:    "missing mapped column",
+			csv:     "date,amount\n2026-01-05,42.50\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSV(strings.NewReader(tt.csv), tt.profile)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ParseCSV() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCSV() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseCSV() = %d records, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// stmtTrnRE matches one <STMTTRN>...</STMTTRN> block. OFX/QFX is SGML,
+// not XML, so closing tags on leaf elements are routinely omitted; we
+// don't attempt a full SGML parse, only extract the handful of fields
+// Budgify needs.
+var stmtTrnRE = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+
+var ofxFieldRE = regexp.MustCompile(`(?i)<(DTPOSTED|TRNAMT|NAME|MEMO)>([^<\r\n]*)`)
+
+// ParseOFX reads transactions out of an OFX or QFX document.
+func ParseOFX(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, block := range stmtTrnRE.FindAllStringSubmatch(string(data), -1) {
+		fields := map[string]string{}
+		for _, m := range ofxFieldRE.FindAllStringSubmatch(block[1], -1) {
+			fields[strings.ToUpper(m[1])] = strings.TrimSpace(m[2])
+		}
+
+		date, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(fields["TRNAMT"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("importer: parse OFX amount %q: %w", fields["TRNAMT"], err)
+		}
+
+		description := fields["NAME"]
+		if description == "" {
+			description = fields["MEMO"]
+		}
+
+		records = append(records, Record{Date: date, Amount: amount, Description: description})
+	}
+	return records, nil
+}
+
+// parseOFXDate converts OFX's "YYYYMMDDHHMMSS[.sss][tz]" timestamps (only
+// the leading YYYYMMDD is ever required) into "YYYY-MM-DD".
+func parseOFXDate(raw string) (string, error) {
+	if len(raw) < 8 {
+		return "", fmt.Errorf("importer: invalid OFX date %q", raw)
+	}
+	y, m, d := raw[0:4], raw[4:6], raw[6:8]
+	return fmt.Sprintf("%s-%s-%s", y, m, d), nil
+}
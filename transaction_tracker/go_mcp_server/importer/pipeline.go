@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"budgify/db"
+)
+
+// Categorizer assigns a category to a transaction description, as
+// implemented by the budget package's rule engine.
+type Categorizer interface {
+	Categorize(ctx context.Context, description string) (string, bool)
+}
+
+// Pipeline runs imported records through dedup + categorization and
+// inserts the survivors into the transactions table.
+type Pipeline struct {
+	db         *db.DB
+	categorize Categorizer
+}
+
+// NewPipeline builds a Pipeline that inserts into conn, auto-categorizing
+// uncategorized records with categorize.
+func NewPipeline(conn *db.DB, categorize Categorizer) *Pipeline {
+	return &Pipeline{db: conn, categorize: categorize}
+}
+
+const importHashSchemaSQL = `
+CREATE TABLE IF NOT EXISTS import_hashes (
+	hash TEXT PRIMARY KEY
+);
+`
+
+// EnsureHashSchema creates the import_hashes dedup table if it does not
+// already exist.
+func EnsureHashSchema(ctx context.Context, conn *db.DB) error {
+	_, err := conn.ExecContext(ctx, importHashSchemaSQL)
+	return err
+}
+
+// Run deduplicates records against previously imported rows (by a hash
+// of date+amount+description), auto-categorizes the rest, and inserts
+// them all inside a single DB transaction.
+func (p *Pipeline) Run(ctx context.Context, records []Record) (Summary, error) {
+	var summary Summary
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return summary, err
+	}
+	defer tx.Rollback()
+
+	for _, rec := range records {
+		hash := recordHash(rec)
+
+		var exists int
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM import_hashes WHERE hash = ?`, hash).Scan(&exists)
+		if err == nil {
+			summary.SkippedDuplicates++
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return summary, err
+		}
+
+		category, categorized := p.categorize.Categorize(ctx, rec.Description)
+		if categorized {
+			summary.Categorized++
+		} else {
+			summary.Uncategorized++
+			category = "uncategorized"
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO transactions (date, category, description, amount) VALUES (?, ?, ?, ?)`,
+			rec.Date, category, rec.Description, rec.Amount); err != nil {
+			return summary, err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO import_hashes (hash) VALUES (?)`, hash); err != nil {
+			return summary, err
+		}
+		summary.Inserted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+func recordHash(r Record) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%.2f|%s", r.Date, r.Amount, r.Description)))
+	return hex.EncodeToString(sum[:])
+}
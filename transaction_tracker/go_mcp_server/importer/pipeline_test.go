@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"budgify/db"
+)
+
+// stubCategorizer categorizes "coffee" descriptions as "dining" and
+// leaves everything else uncategorized.
+type stubCategorizer struct{}
+
+func (stubCategorizer) Categorize(ctx context.Context, description string) (string, bool) {
+	if description == "coffee" {
+		return "dining", true
+	}
+	return "", false
+}
+
+func newTestPipelineDB(t *testing.T) *db.DB {
+	t.Helper()
+	conn, err := db.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open in-memory db: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := context.Background()
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE transactions (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			date        TEXT NOT NULL,
+			category    TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			amount      REAL NOT NULL
+		);
+	`); err != nil {
+		t.Fatalf("create transactions table: %v", err)
+	}
+	if err := EnsureHashSchema(ctx, conn); err != nil {
+		t.Fatalf("ensure hash schema: %v", err)
+	}
+	return conn
+}
+
+func TestPipeline_Run(t *testing.T) {
+	db := newTestPipelineDB(t)
+	pipeline := NewPipeline(db, stubCategorizer{})
+	ctx := context.Background()
+
+	records := []Record{
+		{Date: "2026-01-05", Amount: 5.50, Description: "coffee"},
+		{Date: "2026-01-06", Amount: 20.00, Description: "gas"},
+	}
+
+	summary, err := pipeline.Run(ctx, records)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Inserted != 2 || summary.Categorized != 1 || summary.Uncategorized != 1 || summary.SkippedDuplicates != 0 {
+		t.Errorf("Run() summary = %+v, want {Inserted:2 Categorized:1 Uncategorized:1 SkippedDuplicates:0}", summary)
+	}
+
+	// Re-running the same records should dedupe every row.
+	summary, err = pipeline.Run(ctx, records)
+	if err != nil {
+		t.Fatalf("second Run() error = %v", err)
+	}
+	if summary.SkippedDuplicates != 2 || summary.Inserted != 0 {
+		t.Errorf("second Run() summary = %+v, want all rows skipped as duplicates", summary)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM transactions`).Scan(&count); err != nil {
+		t.Fatalf("count transactions: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("transactions table has %d rows, want 2", count)
+	}
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"budgify/db"
+)
+
+// Profile is a user-configured column mapping for one bank's CSV export,
+// persisted per account (e.g. "chase_checking") so it only has to be
+// set up once.
+type Profile struct {
+	Name              string `json:"name"`
+	DateColumn        string `json:"date_column"`
+	AmountColumn      string `json:"amount_column"`
+	DescriptionColumn string `json:"description_column"`
+	// DateFormat is a Go reference-time layout; defaults to "2006-01-02".
+	DateFormat string `json:"date_format"`
+}
+
+func (p Profile) withDefaults() Profile {
+	if p.DateFormat == "" {
+		p.DateFormat = "2006-01-02"
+	}
+	if p.DateColumn == "" {
+		p.DateColumn = "date"
+	}
+	if p.AmountColumn == "" {
+		p.AmountColumn = "amount"
+	}
+	if p.DescriptionColumn == "" {
+		p.DescriptionColumn = "description"
+	}
+	return p
+}
+
+// ProfileStore persists account profiles.
+type ProfileStore interface {
+	Get(ctx context.Context, name string) (Profile, error)
+	Save(ctx context.Context, p Profile) error
+}
+
+// SQLiteProfileStore implements ProfileStore against an import_profiles table.
+type SQLiteProfileStore struct {
+	db *db.DB
+}
+
+// NewSQLiteProfileStore wraps conn in a ProfileStore.
+func NewSQLiteProfileStore(conn *db.DB) *SQLiteProfileStore {
+	return &SQLiteProfileStore{db: conn}
+}
+
+const profileSchemaSQL = `
+CREATE TABLE IF NOT EXISTS import_profiles (
+	name   TEXT PRIMARY KEY,
+	config TEXT NOT NULL
+);
+`
+
+// EnsureSchema creates the import_profiles table if it does not already exist.
+func EnsureSchema(ctx context.Context, conn *db.DB) error {
+	_, err := conn.ExecContext(ctx, profileSchemaSQL)
+	return err
+}
+
+func (s *SQLiteProfileStore) Get(ctx context.Context, name string) (Profile, error) {
+	var config string
+	err := s.db.QueryRowContext(ctx, `SELECT config FROM import_profiles WHERE name = ?`, name).Scan(&config)
+	if err == sql.ErrNoRows {
+		return Profile{}, fmt.Errorf("importer: no profile named %q", name)
+	}
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var p Profile
+	if err := json.Unmarshal([]byte(config), &p); err != nil {
+		return Profile{}, err
+	}
+	return p.withDefaults(), nil
+}
+
+func (s *SQLiteProfileStore) Save(ctx context.Context, p Profile) error {
+	config, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO import_profiles (name, config) VALUES (?, ?)
+		 ON CONFLICT(name) DO UPDATE SET config = excluded.config`,
+		p.Name, string(config))
+	return err
+}
+
+var _ ProfileStore = (*SQLiteProfileStore)(nil)
@@ -0,0 +1,19 @@
+// Package importer bulk-loads transactions from bank statement exports
+// (CSV or OFX/QFX) instead of requiring users to hand-insert rows.
+package importer
+
+// Record is one normalized statement line, parsed out of either a CSV
+// row or an OFX STMTTRN block, ready to be deduplicated and inserted.
+type Record struct {
+	Date        string // YYYY-MM-DD
+	Amount      float64
+	Description string
+}
+
+// Summary reports what a Pipeline.Run call did.
+type Summary struct {
+	Inserted          int `json:"inserted"`
+	SkippedDuplicates int `json:"skipped_duplicates"`
+	Categorized       int `json:"categorized"`
+	Uncategorized     int `json:"uncategorized"`
+}
@@ -1,27 +1,61 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/http/fcgi"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/gorilla/mux"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
+
+	"budgify/budget"
+	"budgify/config"
+	"budgify/db"
+	"budgify/importer"
+	"budgify/mcp"
+	"budgify/repository"
 )
 
-// OpenDB opens a SQLite database located at path.
-func OpenDB(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", path)
-	if err != nil {
-		return nil, err
+// OpenDB opens the SQL database described by dbCfg, dispatching to the
+// driver named by dbCfg.Type. For sqlite3, a relative DSN is resolved
+// against baseDir.
+func OpenDB(dbCfg config.DBConfig, baseDir string) (*db.DB, error) {
+	var driver string
+	dsn := dbCfg.DSN
+	switch dbCfg.Type {
+	case config.DBTypeSQLite3:
+		driver = "sqlite3"
+		if baseDir != "" && !filepath.IsAbs(dsn) {
+			dsn = filepath.Join(baseDir, dsn)
+		}
+	case config.DBTypeMySQL:
+		driver = "mysql"
+	case config.DBTypePostgres:
+		driver = "postgres"
+	default:
+		return nil, fmt.Errorf("openDB: unsupported db type %v", dbCfg.Type)
 	}
-	return db, nil
+
+	return db.Open(driver, dsn)
 }
 
 // GetSpendByCategoryMonth returns monthly spend aggregated by category.
-func GetSpendByCategoryMonth(db *sql.DB) ([]map[string]interface{}, error) {
-	rows, err := db.Query(`
+func GetSpendByCategoryMonth(ctx context.Context, conn *db.DB) ([]map[string]interface{}, error) {
+	rows, err := conn.QueryContext(ctx, `
         SELECT strftime('%Y-%m', date) AS month,
                category,
                ROUND(SUM(amount), 2) AS total_spent
@@ -52,17 +86,39 @@ func GetSpendByCategoryMonth(db *sql.DB) ([]map[string]interface{}, error) {
 
 // Server wraps the database and exposes HTTP handlers.
 type Server struct {
-	db *sql.DB
+	db       *db.DB
+	repo     repository.TransactionRepo
+	budgets  budget.Repo
+	profiles importer.ProfileStore
 }
 
-// NewServer creates a new Server.
-func NewServer(db *sql.DB) *Server {
-	return &Server{db: db}
+// NewServer creates a new Server, bootstrapping the transactions table
+// along with the budgets/rules and import-profile schema.
+func NewServer(conn *db.DB) *Server {
+	ctx := context.Background()
+	if err := repository.EnsureSchema(ctx, conn); err != nil {
+		log.Printf("ensure transactions schema: %v", err)
+	}
+	if err := budget.EnsureSchema(ctx, conn); err != nil {
+		log.Printf("ensure budgets schema: %v", err)
+	}
+	if err := importer.EnsureSchema(ctx, conn); err != nil {
+		log.Printf("ensure import profile schema: %v", err)
+	}
+	if err := importer.EnsureHashSchema(ctx, conn); err != nil {
+		log.Printf("ensure import dedup schema: %v", err)
+	}
+	return &Server{
+		db:       conn,
+		repo:     repository.NewSQLiteTransactionRepo(conn),
+		budgets:  budget.NewSQLiteRepo(conn),
+		profiles: importer.NewSQLiteProfileStore(conn),
+	}
 }
 
 // handleGetSpendByCategoryMonth writes the aggregated spend as JSON.
 func (s *Server) handleGetSpendByCategoryMonth(w http.ResponseWriter, r *http.Request) {
-	data, err := GetSpendByCategoryMonth(s.db)
+	data, err := GetSpendByCategoryMonth(r.Context(), s.db)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -73,26 +129,276 @@ func (s *Server) handleGetSpendByCategoryMonth(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// routes registers HTTP routes.
-func (s *Server) routes() {
-	http.HandleFunc("/get_spend_by_category_month", s.handleGetSpendByCategoryMonth)
+// routes builds the HTTP router for all of Budgify's REST endpoints.
+func (s *Server) routes() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/get_spend_by_category_month", s.handleGetSpendByCategoryMonth).Methods(http.MethodGet)
+
+	r.HandleFunc("/transactions", s.handleListTransactions).Methods(http.MethodGet)
+	r.HandleFunc("/transactions", s.handleCreateTransaction).Methods(http.MethodPost)
+	r.HandleFunc("/transactions/{id:[0-9]+}", s.handleGetTransaction).Methods(http.MethodGet)
+	r.HandleFunc("/transactions/{id:[0-9]+}", s.handleUpdateTransaction).Methods(http.MethodPut)
+	r.HandleFunc("/transactions/{id:[0-9]+}", s.handleDeleteTransaction).Methods(http.MethodDelete)
+
+	r.HandleFunc("/categories", s.handleListCategories).Methods(http.MethodGet)
+	r.HandleFunc("/spend/summary", s.handleSpendSummary).Methods(http.MethodGet)
+
+	r.HandleFunc("/budgets", s.handleCreateBudget).Methods(http.MethodPost)
+	r.HandleFunc("/budgets/status", s.handleBudgetStatus).Methods(http.MethodGet)
+	r.HandleFunc("/rules", s.handleCreateRule).Methods(http.MethodPost)
+	r.HandleFunc("/rules/{id:[0-9]+}", s.handleDeleteRule).Methods(http.MethodDelete)
+	r.HandleFunc("/transactions/reclassify", s.handleReclassify).Methods(http.MethodPost)
+
+	r.HandleFunc("/import", s.handleImport).Methods(http.MethodPost)
+	r.HandleFunc("/import/profiles", s.handleSaveImportProfile).Methods(http.MethodPost)
+
+	return r
+}
+
+// SpendByCategoryMonth implements mcp.Store.
+func (s *Server) SpendByCategoryMonth(ctx context.Context) ([]mcp.CategorySpend, error) {
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT strftime('%Y-%m', date) AS month,
+               category,
+               ROUND(SUM(amount), 2) AS total_spent
+        FROM transactions
+        GROUP BY month, category
+        ORDER BY month, category;
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []mcp.CategorySpend
+	for rows.Next() {
+		var r mcp.CategorySpend
+		if err := rows.Scan(&r.Month, &r.Category, &r.TotalSpent); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// SpendByMonth implements mcp.Store.
+func (s *Server) SpendByMonth(ctx context.Context) ([]mcp.MonthSpend, error) {
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT strftime('%Y-%m', date) AS month,
+               ROUND(SUM(amount), 2) AS total_spent
+        FROM transactions
+        GROUP BY month
+        ORDER BY month;
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []mcp.MonthSpend
+	for rows.Next() {
+		var r mcp.MonthSpend
+		if err := rows.Scan(&r.Month, &r.TotalSpent); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// ListTransactions implements mcp.Store.
+func (s *Server) ListTransactions(ctx context.Context, filter mcp.TransactionFilter) ([]mcp.Transaction, error) {
+	rows, err := s.repo.List(ctx, repository.ListFilter{
+		Category: filter.Category,
+		Since:    filter.Since,
+		Until:    filter.Until,
+		SortDesc: true,
+		Limit:    filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]mcp.Transaction, len(rows))
+	for i, t := range rows {
+		results[i] = mcp.Transaction(t)
+	}
+	return results, nil
+}
+
+// AddTransaction implements mcp.Store.
+func (s *Server) AddTransaction(ctx context.Context, t mcp.Transaction) (int64, error) {
+	return s.repo.Create(ctx, repository.Transaction(t))
+}
+
+// RunReadOnlySQL implements mcp.Store. It rejects anything that isn't a
+// single SELECT so MCP clients cannot use it to mutate data. This matters
+// because database/sql drivers (mattn/go-sqlite3 included) happily run
+// every statement in a semicolon-separated batch, so a naive "starts with
+// SELECT" check would let "SELECT 1; DROP TABLE transactions;" through.
+func (s *Server) RunReadOnlySQL(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	if !isSingleSelectStatement(query) {
+		return nil, fmt.Errorf("run_sql only permits a single SELECT statement")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(cols))
+		for i, col := range cols {
+			row[col] = values[i]
+		}
+		results = append(results, row)
+	}
+	return results, rows.Err()
+}
+
+// isSingleSelectStatement reports whether query is exactly one SELECT
+// statement (an optional single trailing semicolon is allowed). Any
+// semicolon before that lets a caller smuggle a second statement into
+// the same batch, which go-sqlite3 would otherwise execute as well.
+func isSingleSelectStatement(query string) bool {
+	trimmed := strings.TrimSpace(query)
+	trimmed = strings.TrimSuffix(trimmed, ";")
+	if !strings.HasPrefix(strings.ToUpper(trimmed), "SELECT") {
+		return false
+	}
+	return !strings.Contains(trimmed, ";")
+}
+
+// Schema implements mcp.Store, exposing the sqlite schema as an MCP resource.
+func (s *Server) Schema(ctx context.Context) (string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT sql FROM sqlite_master WHERE sql IS NOT NULL ORDER BY name`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var stmts []string
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return "", err
+		}
+		stmts = append(stmts, stmt+";")
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(stmts, "\n"), nil
+}
+
+// runHTTP serves the existing plain HTTP endpoint(s).
+func runHTTP(server *Server, bindAddr string) error {
+	srv := &http.Server{Addr: bindAddr, Handler: server.routes()}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Budgify HTTP server listening on %s", bindAddr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down Budgify HTTP server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// runFCGI serves the HTTP handlers over FastCGI instead of plain HTTP,
+// for deployment behind a webserver like nginx.
+func runFCGI(server *Server, bindAddr string) error {
+	l, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		return err
+	}
+	log.Printf("Budgify FCGI server listening on %s", bindAddr)
+	return fcgi.Serve(l, server.routes())
+}
+
+// runMCPStdio serves MCP over JSON-RPC 2.0 on stdin/stdout until stdin
+// closes or the process receives an interrupt.
+func runMCPStdio(server *Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Budgify MCP server serving JSON-RPC 2.0 over stdio")
+	mcpServer := mcp.NewServer(server)
+	return mcpServer.ServeStdio(ctx, os.Stdin, os.Stdout)
 }
 
 func main() {
-	dbPath := os.Getenv("BUDGIFY_DB")
-	if dbPath == "" {
-		dbPath = "budget.db"
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImportCLI(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	confPath := flag.String("conf", os.Getenv("BUDGIFY_CONF"), "path to a YAML config file")
+	mode := flag.String("mode", os.Getenv("BUDGIFY_MODE"), "server mode: \"http\", \"fcgi\", or \"mcp\" (overrides the config file)")
+	flag.Parse()
+
+	cfg, err := config.Load(*confPath)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if *mode != "" {
+		cfg.Mode = *mode
 	}
+	if dbPath := os.Getenv("BUDGIFY_DB"); dbPath != "" {
+		cfg.DB = config.DBConfig{Type: config.DBTypeSQLite3, DSN: dbPath}
+	}
+
+	log.Printf("using %s backend %q", cfg.DB.Type, cfg.DB.DSN)
 
-	db, err := OpenDB(dbPath)
+	db, err := OpenDB(cfg.DB, cfg.BaseDir)
 	if err != nil {
 		log.Fatalf("failed to open db: %v", err)
 	}
 	defer db.Close()
 
 	server := NewServer(db)
-	server.routes()
 
-	log.Println("MCP server listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	switch cfg.Mode {
+	case "http":
+		err = runHTTP(server, cfg.BindAddr)
+	case "fcgi":
+		err = runFCGI(server, cfg.BindAddr)
+	case "mcp":
+		err = runMCPStdio(server)
+	default:
+		log.Fatalf("unknown mode %q: must be \"http\", \"fcgi\", or \"mcp\"", cfg.Mode)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
 }
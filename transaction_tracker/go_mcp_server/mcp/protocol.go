@@ -0,0 +1,119 @@
+// Package mcp implements a minimal Model Context Protocol server: JSON-RPC
+// 2.0 framed over stdio, supporting the handshake and tool/resource
+// discovery methods an MCP client expects.
+package mcp
+
+import "encoding/json"
+
+const protocolVersion = "2024-11-05"
+
+// Request is an incoming JSON-RPC 2.0 request or notification. ID is nil
+// for notifications.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is an outgoing JSON-RPC 2.0 response.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// InitializeResult is returned in response to the "initialize" method.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	ServerInfo      ServerInfo         `json:"serverInfo"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+}
+
+// ServerInfo identifies this MCP server to a connecting client.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ServerCapabilities advertises which MCP feature areas this server supports.
+type ServerCapabilities struct {
+	Tools     *struct{} `json:"tools,omitempty"`
+	Resources *struct{} `json:"resources,omitempty"`
+}
+
+// Tool describes a single callable tool, advertised via "tools/list".
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// ToolsListResult is the result of "tools/list".
+type ToolsListResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// ToolCallParams is the params of a "tools/call" request.
+type ToolCallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// ToolCallResult is the result of "tools/call".
+type ToolCallResult struct {
+	Content []ToolContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+// ToolContent is a single piece of content returned by a tool call. MCP
+// supports several content types; we only emit "text".
+type ToolContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Resource describes a readable resource, advertised via "resources/list".
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourcesListResult is the result of "resources/list".
+type ResourcesListResult struct {
+	Resources []Resource `json:"resources"`
+}
+
+// ResourceReadParams is the params of a "resources/read" request.
+type ResourceReadParams struct {
+	URI string `json:"uri"`
+}
+
+// ResourceReadResult is the result of "resources/read".
+type ResourceReadResult struct {
+	Contents []ResourceContent `json:"contents"`
+}
+
+// ResourceContent is a single resource payload.
+type ResourceContent struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
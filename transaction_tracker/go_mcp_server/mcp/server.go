@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Server is a Model Context Protocol server that speaks JSON-RPC 2.0,
+// newline-delimited, over an io.Reader/io.Writer pair (typically stdin
+// and stdout when run in MCP-stdio mode).
+type Server struct {
+	store Store
+}
+
+// NewServer creates an MCP server backed by store.
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+// ServeStdio reads JSON-RPC requests from r, one per line, and writes
+// responses to w until r is exhausted or ctx is cancelled.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req Request
+		if err := json.Unmarshal(line, &req); err != nil {
+			writeResponse(w, Response{JSONRPC: "2.0", Error: &Error{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+
+		resp := s.handle(ctx, req)
+		if req.ID == nil {
+			// Notification: no response expected.
+			continue
+		}
+		if err := writeResponse(w, resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func writeResponse(w io.Writer, resp Response) error {
+	resp.JSONRPC = "2.0"
+	enc := json.NewEncoder(w)
+	return enc.Encode(resp)
+}
+
+func (s *Server) handle(ctx context.Context, req Request) Response {
+	resp := Response{ID: req.ID}
+
+	result, err := s.dispatch(ctx, req.Method, req.Params)
+	if err != nil {
+		resp.Error = toRPCError(err)
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return InitializeResult{
+			ProtocolVersion: protocolVersion,
+			ServerInfo:      ServerInfo{Name: "budgify", Version: "0.1.0"},
+			Capabilities: ServerCapabilities{
+				Tools:     &struct{}{},
+				Resources: &struct{}{},
+			},
+		}, nil
+
+	case "notifications/initialized", "initialized":
+		return nil, nil
+
+	case "tools/list":
+		defs := s.tools()
+		list := make([]Tool, 0, len(defs))
+		for _, d := range defs {
+			list = append(list, d.Tool)
+		}
+		return ToolsListResult{Tools: list}, nil
+
+	case "tools/call":
+		var p ToolCallParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{code: codeInvalidParams, err: err}
+		}
+		for _, d := range s.tools() {
+			if d.Name == p.Name {
+				out, err := d.call(ctx, s, p.Arguments)
+				if err != nil {
+					return ToolCallResult{
+						IsError: true,
+						Content: []ToolContent{{Type: "text", Text: err.Error()}},
+					}, nil
+				}
+				text, err := json.Marshal(out)
+				if err != nil {
+					return nil, &rpcError{code: codeInternalError, err: err}
+				}
+				return ToolCallResult{Content: []ToolContent{{Type: "text", Text: string(text)}}}, nil
+			}
+		}
+		return nil, &rpcError{code: codeMethodNotFound, err: fmt.Errorf("unknown tool: %s", p.Name)}
+
+	case "resources/list":
+		return ResourcesListResult{Resources: s.resources()}, nil
+
+	case "resources/read":
+		var p ResourceReadParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{code: codeInvalidParams, err: err}
+		}
+		content, err := s.readResource(ctx, p.URI)
+		if err != nil {
+			return nil, &rpcError{code: codeInvalidParams, err: err}
+		}
+		return ResourceReadResult{Contents: []ResourceContent{content}}, nil
+
+	default:
+		return nil, &rpcError{code: codeMethodNotFound, err: fmt.Errorf("unknown method: %s", method)}
+	}
+}
+
+// rpcError carries a JSON-RPC error code alongside the underlying error.
+type rpcError struct {
+	code int
+	err  error
+}
+
+func (e *rpcError) Error() string { return e.err.Error() }
+
+func toRPCError(err error) *Error {
+	if re, ok := err.(*rpcError); ok {
+		return &Error{Code: re.code, Message: re.err.Error()}
+	}
+	return &Error{Code: codeInternalError, Message: err.Error()}
+}
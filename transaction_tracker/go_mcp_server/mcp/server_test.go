@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeStore is an in-memory Store used to exercise dispatch without a
+// real database.
+type fakeStore struct {
+	transactions []Transaction
+	nextID       int64
+	schema       string
+}
+
+func (f *fakeStore) SpendByCategoryMonth(ctx context.Context) ([]CategorySpend, error) {
+	return []CategorySpend{{Month: "2026-01", Category: "groceries", TotalSpent: 42.5}}, nil
+}
+
+func (f *fakeStore) SpendByMonth(ctx context.Context) ([]MonthSpend, error) {
+	return []MonthSpend{{Month: "2026-01", TotalSpent: 42.5}}, nil
+}
+
+func (f *fakeStore) ListTransactions(ctx context.Context, filter TransactionFilter) ([]Transaction, error) {
+	return f.transactions, nil
+}
+
+func (f *fakeStore) AddTransaction(ctx context.Context, t Transaction) (int64, error) {
+	f.nextID++
+	t.ID = f.nextID
+	f.transactions = append(f.transactions, t)
+	return t.ID, nil
+}
+
+// RunReadOnlySQL mimics the repo's real readonly guard closely enough to
+// exercise dispatch's error handling: only a single SELECT is permitted.
+func (f *fakeStore) RunReadOnlySQL(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if !strings.HasPrefix(strings.ToUpper(trimmed), "SELECT") || strings.Contains(trimmed, ";") {
+		return nil, fmt.Errorf("run_sql only permits a single SELECT statement")
+	}
+	return []map[string]interface{}{{"ok": true}}, nil
+}
+
+func (f *fakeStore) Schema(ctx context.Context) (string, error) {
+	return f.schema, nil
+}
+
+func rawID(t *testing.T, id int) json.RawMessage {
+	t.Helper()
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("marshal id: %v", err)
+	}
+	return b
+}
+
+func TestServer_Dispatch_Initialize(t *testing.T) {
+	s := NewServer(&fakeStore{})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "initialize"})
+	if resp.Error != nil {
+		t.Fatalf("initialize: unexpected error %+v", resp.Error)
+	}
+	result, ok := resp.Result.(InitializeResult)
+	if !ok {
+		t.Fatalf("initialize: result is %T, want InitializeResult", resp.Result)
+	}
+	if result.ProtocolVersion != protocolVersion {
+		t.Errorf("ProtocolVersion = %q, want %q", result.ProtocolVersion, protocolVersion)
+	}
+}
+
+func TestServer_Dispatch_ToolsList(t *testing.T) {
+	s := NewServer(&fakeStore{})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "tools/list"})
+	if resp.Error != nil {
+		t.Fatalf("tools/list: unexpected error %+v", resp.Error)
+	}
+	result, ok := resp.Result.(ToolsListResult)
+	if !ok {
+		t.Fatalf("tools/list: result is %T, want ToolsListResult", resp.Result)
+	}
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	for _, want := range []string{"get_spend_by_category_month", "list_transactions", "add_transaction", "run_sql"} {
+		found := false
+		for _, n := range names {
+			if n == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("tools/list: missing tool %q, got %v", want, names)
+		}
+	}
+}
+
+func TestServer_Dispatch_ToolsCall_AddTransaction(t *testing.T) {
+	store := &fakeStore{}
+	s := NewServer(store)
+	args, _ := json.Marshal(ToolCallParams{
+		Name:      "add_transaction",
+		Arguments: json.RawMessage(`{"date":"2026-01-05","category":"groceries","amount":10.5}`),
+	})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "tools/call", Params: args})
+	if resp.Error != nil {
+		t.Fatalf("tools/call: unexpected RPC error %+v", resp.Error)
+	}
+	result, ok := resp.Result.(ToolCallResult)
+	if !ok {
+		t.Fatalf("tools/call: result is %T, want ToolCallResult", resp.Result)
+	}
+	if result.IsError {
+		t.Fatalf("tools/call: unexpected tool error %+v", result.Content)
+	}
+	if len(store.transactions) != 1 {
+		t.Fatalf("AddTransaction not forwarded to store: got %d transactions", len(store.transactions))
+	}
+}
+
+func TestServer_Dispatch_ToolsCall_UnknownTool(t *testing.T) {
+	s := NewServer(&fakeStore{})
+	args, _ := json.Marshal(ToolCallParams{Name: "does_not_exist"})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "tools/call", Params: args})
+	if resp.Error == nil {
+		t.Fatal("tools/call with unknown tool: want RPC error, got nil")
+	}
+	if resp.Error.Code != codeMethodNotFound {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, codeMethodNotFound)
+	}
+}
+
+func TestServer_Dispatch_ToolsCall_RunSQL_ReadonlyEnforced(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantError bool
+	}{
+		{name: "plain select", query: "SELECT * FROM transactions", wantError: false},
+		{name: "select with trailing semicolon", query: "SELECT 1;", wantError: false},
+		{name: "stacked statement rejected", query: "SELECT 1; DROP TABLE transactions;", wantError: true},
+		{name: "non-select rejected", query: "DELETE FROM transactions", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(&fakeStore{})
+			args, _ := json.Marshal(ToolCallParams{
+				Name:      "run_sql",
+				Arguments: json.RawMessage(fmt.Sprintf(`{"query":%q}`, tt.query)),
+			})
+			resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "tools/call", Params: args})
+			if resp.Error != nil {
+				t.Fatalf("tools/call: unexpected RPC error %+v", resp.Error)
+			}
+			result, ok := resp.Result.(ToolCallResult)
+			if !ok {
+				t.Fatalf("tools/call: result is %T, want ToolCallResult", resp.Result)
+			}
+			if result.IsError != tt.wantError {
+				t.Errorf("run_sql(%q): IsError = %v, want %v (content %+v)", tt.query, result.IsError, tt.wantError, result.Content)
+			}
+		})
+	}
+}
+
+func TestServer_Dispatch_ResourcesRead(t *testing.T) {
+	s := NewServer(&fakeStore{schema: "CREATE TABLE transactions (...)"})
+	args, _ := json.Marshal(ResourceReadParams{URI: "schema://budgify"})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "resources/read", Params: args})
+	if resp.Error != nil {
+		t.Fatalf("resources/read: unexpected error %+v", resp.Error)
+	}
+	result, ok := resp.Result.(ResourceReadResult)
+	if !ok {
+		t.Fatalf("resources/read: result is %T, want ResourceReadResult", resp.Result)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Text != "CREATE TABLE transactions (...)" {
+		t.Errorf("resources/read: got %+v", result.Contents)
+	}
+}
+
+func TestServer_Dispatch_ResourcesRead_UnknownURI(t *testing.T) {
+	s := NewServer(&fakeStore{})
+	args, _ := json.Marshal(ResourceReadParams{URI: "schema://nope"})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "resources/read", Params: args})
+	if resp.Error == nil {
+		t.Fatal("resources/read with unknown uri: want error, got nil")
+	}
+}
+
+func TestServer_Dispatch_UnknownMethod(t *testing.T) {
+	s := NewServer(&fakeStore{})
+	resp := s.handle(context.Background(), Request{ID: rawID(t, 1), Method: "bogus/method"})
+	if resp.Error == nil {
+		t.Fatal("unknown method: want error, got nil")
+	}
+	if resp.Error.Code != codeMethodNotFound {
+		t.Errorf("error code = %d, want %d", resp.Error.Code, codeMethodNotFound)
+	}
+}
@@ -0,0 +1,45 @@
+package mcp
+
+import "context"
+
+// CategorySpend is one row of spend aggregated by month and category.
+type CategorySpend struct {
+	Month      string  `json:"month"`
+	Category   string  `json:"category"`
+	TotalSpent float64 `json:"total_spent"`
+}
+
+// MonthSpend is one row of spend aggregated by month.
+type MonthSpend struct {
+	Month      string  `json:"month"`
+	TotalSpent float64 `json:"total_spent"`
+}
+
+// TransactionFilter narrows a ListTransactions call.
+type TransactionFilter struct {
+	Category string
+	Since    string
+	Until    string
+	Limit    int
+}
+
+// Transaction is a single row of the transactions table.
+type Transaction struct {
+	ID          int64   `json:"id"`
+	Date        string  `json:"date"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// Store is the subset of Budgify's data access that the MCP server needs.
+// main wires its *sql.DB-backed Server into this interface so the mcp
+// package never has to import package main.
+type Store interface {
+	SpendByCategoryMonth(ctx context.Context) ([]CategorySpend, error)
+	SpendByMonth(ctx context.Context) ([]MonthSpend, error)
+	ListTransactions(ctx context.Context, filter TransactionFilter) ([]Transaction, error)
+	AddTransaction(ctx context.Context, t Transaction) (int64, error)
+	RunReadOnlySQL(ctx context.Context, query string) ([]map[string]interface{}, error)
+	Schema(ctx context.Context) (string, error)
+}
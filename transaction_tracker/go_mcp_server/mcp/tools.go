@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// toolDef pairs a Tool's advertised metadata with its dispatch function.
+type toolDef struct {
+	Tool
+	call func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error)
+}
+
+func (s *Server) tools() []toolDef {
+	return []toolDef{
+		{
+			Tool: Tool{
+				Name:        "get_spend_by_category_month",
+				Description: "Return total spend grouped by month and category.",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+			},
+			call: func(ctx context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+				return s.store.SpendByCategoryMonth(ctx)
+			},
+		},
+		{
+			Tool: Tool{
+				Name:        "get_spend_by_month",
+				Description: "Return total spend grouped by month.",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+			},
+			call: func(ctx context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+				return s.store.SpendByMonth(ctx)
+			},
+		},
+		{
+			Tool: Tool{
+				Name:        "list_transactions",
+				Description: "List transactions, optionally filtered by category and date range.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"category": {"type": "string"},
+						"since": {"type": "string", "description": "YYYY-MM-DD"},
+						"until": {"type": "string", "description": "YYYY-MM-DD"},
+						"limit": {"type": "integer"}
+					}
+				}`),
+			},
+			call: func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error) {
+				var filter TransactionFilter
+				if len(args) > 0 {
+					if err := json.Unmarshal(args, &filter); err != nil {
+						return nil, fmt.Errorf("invalid arguments: %w", err)
+					}
+				}
+				return s.store.ListTransactions(ctx, filter)
+			},
+		},
+		{
+			Tool: Tool{
+				Name:        "add_transaction",
+				Description: "Insert a new transaction and return its id.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {
+						"date": {"type": "string"},
+						"category": {"type": "string"},
+						"description": {"type": "string"},
+						"amount": {"type": "number"}
+					},
+					"required": ["date", "category", "amount"]
+				}`),
+			},
+			call: func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error) {
+				var t Transaction
+				if err := json.Unmarshal(args, &t); err != nil {
+					return nil, fmt.Errorf("invalid arguments: %w", err)
+				}
+				id, err := s.store.AddTransaction(ctx, t)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]int64{"id": id}, nil
+			},
+		},
+		{
+			Tool: Tool{
+				Name:        "run_sql",
+				Description: "Run a read-only SQL query against the Budgify database and return the rows.",
+				InputSchema: json.RawMessage(`{
+					"type": "object",
+					"properties": {"query": {"type": "string"}},
+					"required": ["query"]
+				}`),
+			},
+			call: func(ctx context.Context, s *Server, args json.RawMessage) (interface{}, error) {
+				var params struct {
+					Query string `json:"query"`
+				}
+				if err := json.Unmarshal(args, &params); err != nil {
+					return nil, fmt.Errorf("invalid arguments: %w", err)
+				}
+				return s.store.RunReadOnlySQL(ctx, params.Query)
+			},
+		},
+	}
+}
+
+func (s *Server) resources() []Resource {
+	return []Resource{
+		{
+			URI:         "schema://budgify",
+			Name:        "Budgify DB schema",
+			Description: "SQL schema of the Budgify sqlite database.",
+			MimeType:    "text/plain",
+		},
+	}
+}
+
+func (s *Server) readResource(ctx context.Context, uri string) (ResourceContent, error) {
+	if uri != "schema://budgify" {
+		return ResourceContent{}, fmt.Errorf("unknown resource: %s", uri)
+	}
+	schema, err := s.store.Schema(ctx)
+	if err != nil {
+		return ResourceContent{}, err
+	}
+	return ResourceContent{URI: uri, MimeType: "text/plain", Text: schema}, nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"budgify/db"
+)
+
+// SQLiteTransactionRepo implements TransactionRepo against the
+// transactions table in a sqlite database.
+type SQLiteTransactionRepo struct {
+	db *db.DB
+}
+
+// NewSQLiteTransactionRepo wraps db in a TransactionRepo.
+func NewSQLiteTransactionRepo(conn *db.DB) *SQLiteTransactionRepo {
+	return &SQLiteTransactionRepo{db: conn}
+}
+
+func (r *SQLiteTransactionRepo) List(ctx context.Context, filter ListFilter) ([]Transaction, error) {
+	query := `SELECT id, date, category, description, amount FROM transactions WHERE 1=1`
+	var args []interface{}
+
+	if filter.Since != "" {
+		query += ` AND date >= ?`
+		args = append(args, filter.Since)
+	}
+	if filter.Until != "" {
+		query += ` AND date <= ?`
+		args = append(args, filter.Until)
+	}
+	if filter.Category != "" {
+		query += ` AND category = ?`
+		args = append(args, filter.Category)
+	}
+	if filter.MinAmount != nil {
+		query += ` AND amount >= ?`
+		args = append(args, *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query += ` AND amount <= ?`
+		args = append(args, *filter.MaxAmount)
+	}
+	if filter.Search != "" {
+		query += ` AND description LIKE ?`
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	sortCol, err := sortColumn(filter.SortBy)
+	if err != nil {
+		return nil, err
+	}
+	query += fmt.Sprintf(" ORDER BY %s", sortCol)
+	if filter.SortDesc {
+		query += " DESC"
+	}
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += ` OFFSET ?`
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []Transaction
+	for rows.Next() {
+		var t Transaction
+		if err := rows.Scan(&t.ID, &t.Date, &t.Category, &t.Description, &t.Amount); err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+	return results, rows.Err()
+}
+
+func sortColumn(sortBy string) (string, error) {
+	switch sortBy {
+	case "", "date":
+		return "date", nil
+	case "amount":
+		return "amount", nil
+	case "category":
+		return "category", nil
+	default:
+		return "", fmt.Errorf("repository: invalid sort field %q", sortBy)
+	}
+}
+
+func (r *SQLiteTransactionRepo) Get(ctx context.Context, id int64) (*Transaction, error) {
+	var t Transaction
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, date, category, description, amount FROM transactions WHERE id = ?`, id,
+	).Scan(&t.ID, &t.Date, &t.Category, &t.Description, &t.Amount)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *SQLiteTransactionRepo) Create(ctx context.Context, t Transaction) (int64, error) {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO transactions (date, category, description, amount) VALUES (?, ?, ?, ?)`,
+		t.Date, t.Category, t.Description, t.Amount)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *SQLiteTransactionRepo) Update(ctx context.Context, id int64, t Transaction) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE transactions SET date = ?, category = ?, description = ?, amount = ? WHERE id = ?`,
+		t.Date, t.Category, t.Description, t.Amount, id)
+	if err != nil {
+		return err
+	}
+	return requireRowAffected(res)
+}
+
+func (r *SQLiteTransactionRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM transactions WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return requireRowAffected(res)
+}
+
+func requireRowAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteTransactionRepo) Categories(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT category FROM transactions ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+func (r *SQLiteTransactionRepo) SpendSummary(ctx context.Context, groupBy string) ([]SpendSummary, error) {
+	var groupExpr string
+	switch groupBy {
+	case "", "month":
+		groupExpr = `strftime('%Y-%m', date)`
+	case "week":
+		groupExpr = `strftime('%Y-%W', date)`
+	case "category":
+		groupExpr = `category`
+	default:
+		return nil, fmt.Errorf("repository: invalid group_by %q", groupBy)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS key, ROUND(SUM(amount), 2) AS total
+		FROM transactions
+		GROUP BY key
+		ORDER BY key;
+	`, groupExpr)
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []SpendSummary
+	for rows.Next() {
+		var s SpendSummary
+		if err := rows.Scan(&s.Key, &s.Total); err != nil {
+			return nil, err
+		}
+		results = append(results, s)
+	}
+	return results, rows.Err()
+}
+
+var _ TransactionRepo = (*SQLiteTransactionRepo)(nil)
+
+// schemaSQL creates the transactions table; used by tests and by fresh
+// sqlite databases via EnsureSchema.
+const schemaSQL = `
+CREATE TABLE IF NOT EXISTS transactions (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	date        TEXT NOT NULL,
+	category    TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	amount      REAL NOT NULL
+);
+`
+
+// EnsureSchema creates the transactions table if it does not already exist.
+func EnsureSchema(ctx context.Context, conn *db.DB) error {
+	_, err := conn.ExecContext(ctx, strings.TrimSpace(schemaSQL))
+	return err
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"budgify/db"
+)
+
+func newTestRepo(t *testing.T) *SQLiteTransactionRepo {
+	t.Helper()
+	conn, err := db.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open in-memory db: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := context.Background()
+	if err := EnsureSchema(ctx, conn); err != nil {
+		t.Fatalf("ensure schema: %v", err)
+	}
+
+	seed := []Transaction{
+		{Date: "2026-01-05", Category: "groceries", Description: "Trader Joe's", Amount: 42.50},
+		{Date: "2026-01-20", Category: "groceries", Description: "Whole Foods", Amount: 61.10},
+		{Date: "2026-02-01", Category: "rent", Description: "February rent", Amount: 1500},
+		{Date: "2026-02-14", Category: "dining", Description: "Valentine's dinner", Amount: 88.00},
+	}
+	repo := NewSQLiteTransactionRepo(conn)
+	for _, tx := range seed {
+		if _, err := repo.Create(ctx, tx); err != nil {
+			t.Fatalf("seed create: %v", err)
+		}
+	}
+	return repo
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestSQLiteTransactionRepo_List(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		filter    ListFilter
+		wantCount int
+	}{
+		{name: "no filter", filter: ListFilter{}, wantCount: 4},
+		{name: "by category", filter: ListFilter{Category: "groceries"}, wantCount: 2},
+		{name: "date range", filter: ListFilter{Since: "2026-02-01", Until: "2026-02-28"}, wantCount: 2},
+		{name: "min amount", filter: ListFilter{MinAmount: floatPtr(100)}, wantCount: 1},
+		{name: "max amount", filter: ListFilter{MaxAmount: floatPtr(50)}, wantCount: 1},
+		{name: "search description", filter: ListFilter{Search: "Whole"}, wantCount: 1},
+		{name: "limit", filter: ListFilter{Limit: 2}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("List() returned %d rows, want %d", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestSQLiteTransactionRepo_List_InvalidSort(t *testing.T) {
+	repo := newTestRepo(t)
+	if _, err := repo.List(context.Background(), ListFilter{SortBy: "bogus"}); err == nil {
+		t.Error("List() with invalid sort field: want error, got nil")
+	}
+}
+
+func TestSQLiteTransactionRepo_CRUD(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, Transaction{Date: "2026-03-01", Category: "misc", Description: "test", Amount: 9.99})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Category != "misc" || got.Amount != 9.99 {
+		t.Errorf("Get() = %+v, want category=misc amount=9.99", got)
+	}
+
+	if err := repo.Update(ctx, id, Transaction{Date: "2026-03-02", Category: "misc", Description: "updated", Amount: 19.99}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err = repo.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() after update error = %v", err)
+	}
+	if got.Description != "updated" || got.Amount != 19.99 {
+		t.Errorf("Get() after update = %+v, want description=updated amount=19.99", got)
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, id); err != ErrNotFound {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteTransactionRepo_Update_NotFound(t *testing.T) {
+	repo := newTestRepo(t)
+	err := repo.Update(context.Background(), 999999, Transaction{Date: "2026-01-01", Category: "x", Amount: 1})
+	if err != ErrNotFound {
+		t.Errorf("Update() on missing id error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteTransactionRepo_Categories(t *testing.T) {
+	repo := newTestRepo(t)
+	got, err := repo.Categories(context.Background())
+	if err != nil {
+		t.Fatalf("Categories() error = %v", err)
+	}
+	want := []string{"dining", "groceries", "rent"}
+	if len(got) != len(want) {
+		t.Fatalf("Categories() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Categories()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSQLiteTransactionRepo_SpendSummary(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		groupBy   string
+		wantRows  int
+		wantError bool
+	}{
+		{groupBy: "month", wantRows: 2},
+		{groupBy: "category", wantRows: 3},
+		{groupBy: "bogus", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			got, err := repo.SpendSummary(ctx, tt.groupBy)
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("SpendSummary() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SpendSummary() error = %v", err)
+			}
+			if len(got) != tt.wantRows {
+				t.Errorf("SpendSummary(%q) returned %d rows, want %d", tt.groupBy, len(got), tt.wantRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package repository separates Budgify's SQL access from its HTTP
+// handlers so each can evolve (and be tested) independently.
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a lookup by id matches no row.
+var ErrNotFound = errors.New("repository: not found")
+
+// Transaction is a single row of the transactions table.
+type Transaction struct {
+	ID          int64   `json:"id"`
+	Date        string  `json:"date"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// ListFilter narrows and paginates a transaction listing.
+type ListFilter struct {
+	Since     string
+	Until     string
+	Category  string
+	MinAmount *float64
+	MaxAmount *float64
+	Search    string
+
+	SortBy   string // "date", "amount", or "category"; defaults to "date"
+	SortDesc bool
+
+	Limit  int
+	Offset int
+}
+
+// SpendSummary is one grouped row returned by SpendSummary, keyed by
+// whatever dimension was grouped on (month, week, or category).
+type SpendSummary struct {
+	Key   string  `json:"key"`
+	Total float64 `json:"total"`
+}
+
+// TransactionRepo is the storage interface HTTP handlers depend on. It
+// has a single SQLite implementation today (see sqlite.go) but keeping
+// handlers coded against the interface lets them be tested without a
+// real database.
+type TransactionRepo interface {
+	List(ctx context.Context, filter ListFilter) ([]Transaction, error)
+	Get(ctx context.Context, id int64) (*Transaction, error)
+	Create(ctx context.Context, t Transaction) (int64, error)
+	Update(ctx context.Context, id int64, t Transaction) error
+	Delete(ctx context.Context, id int64) error
+	Categories(ctx context.Context) ([]string, error)
+	SpendSummary(ctx context.Context, groupBy string) ([]SpendSummary, error)
+}